@@ -0,0 +1,302 @@
+/*
+Copyright 2019 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package v1alpha2 is the v1alpha2 version of the acme.cert-manager.io API
+// group: configuration specific to the ACME Issuer type.
+package v1alpha2
+
+import (
+	"encoding/json"
+
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+)
+
+// ACMEIssuer contains the specification for an ACME issuer. This uses the
+// RFC8555 specification to obtain certificates by completing 'challenges'
+// to prove ownership of domains.
+type ACMEIssuer struct {
+	// Email is the email address to be associated with the ACME account.
+	// This field is optional, but it is strongly recommended to be set.
+	// It will be used to contact you in case of issues with your account or
+	// certificates, including expiry notification emails.
+	// +optional
+	Email string `json:"email,omitempty"`
+
+	// Server is the URL used to access the ACME server's 'directory'
+	// endpoint.
+	Server string `json:"server"`
+
+	// PreferredChain is the chain to use if the ACME server outputs
+	// multiple certificate chains via the RFC8555 `alternate` Link
+	// headers. The first chain whose topmost certificate's Issuer
+	// CommonName matches this value is selected; if no chain matches, the
+	// default chain returned by the ACME server is used instead.
+	// Example: "ISRG Root X1" or "DST Root CA X3".
+	// +optional
+	PreferredChain string `json:"preferredChain,omitempty"`
+
+	// SkipTLSVerify disables validation of the ACME server's TLS
+	// certificate.
+	// +optional
+	SkipTLSVerify bool `json:"skipTLSVerify,omitempty"`
+
+	// DefaultPrivateKeyAlgorithm is used as a Certificate's KeyAlgorithm
+	// when the Certificate itself doesn't specify one, allowing an
+	// operator to change the default for every Certificate issued through
+	// this Issuer without having to set it on each one individually.
+	// +optional
+	DefaultPrivateKeyAlgorithm cmmeta.KeyAlgorithm `json:"defaultPrivateKeyAlgorithm,omitempty"`
+
+	// PrivateKey is the name of a Secret resource that will be used to
+	// store the automatically generated ACME account private key.
+	PrivateKey cmmeta.SecretKeySelector `json:"privateKeySecretRef"`
+
+	// ExternalAccountBinding is a reference to a CA's account, used when
+	// the ACME server requires External Account Binding and the operator
+	// has pre-provisioned a KeyID/HMAC pair themselves. Mutually exclusive
+	// with ZeroSSL, which auto-provisions this instead.
+	// +optional
+	ExternalAccountBinding *ACMEExternalAccountBinding `json:"externalAccountBinding,omitempty"`
+
+	// ZeroSSL configures this issuer to register its ACME account against
+	// ZeroSSL, auto-provisioning External Account Binding credentials from
+	// ZeroSSL.Email rather than requiring them to be supplied up front.
+	// +optional
+	ZeroSSL *ZeroSSLIssuer `json:"zerossl,omitempty"`
+
+	// Solvers is a list of challenge solvers that will be used to solve
+	// ACME challenges for the matching domains.
+	Solvers []ACMEChallengeSolver `json:"solvers,omitempty"`
+}
+
+// ACMEIssuerStatus contains the status of an ACME issuer.
+type ACMEIssuerStatus struct {
+	// URI is the unique account identifier, which can also be used to
+	// retrieve account details from the CA.
+	URI string `json:"uri,omitempty"`
+}
+
+// ACMEExternalAccountBinding is a reference to a CA ACME account that can be
+// used to associate an ACME account with an external account, as defined in
+// RFC8555 section 7.3.4.
+type ACMEExternalAccountBinding struct {
+	// KeyID is the ID of the CA key that the External Account Binding HMAC
+	// key is associated with.
+	KeyID string `json:"keyID"`
+
+	// Key is a reference to a Secret containing the HMAC key used to
+	// validate the External Account Binding.
+	Key cmmeta.SecretKeySelector `json:"keySecretRef"`
+}
+
+// ZeroSSLIssuer configures automatic External Account Binding provisioning
+// against ZeroSSL's "generate EAB credentials" API. Rather than requiring an
+// operator to pre-provision a KeyID/HMAC pair (ExternalAccountBinding), the
+// controller exchanges Email for a KID/HMAC pair at reconcile time and
+// persists the result in the Secret referenced by EABSecretRef.
+type ZeroSSLIssuer struct {
+	// Email is exchanged for EAB credentials via ZeroSSL's EAB endpoint.
+	Email string `json:"email"`
+
+	// EABSecretRef is the Secret the auto-provisioned EAB KeyID/HMAC pair
+	// is written to and read back from on subsequent reconciles.
+	EABSecretRef cmmeta.SecretKeySelector `json:"eabSecretRef"`
+}
+
+// ACMEChallengeSolver configures how ACME challenges should be solved, by
+// either responding to DNS01 or HTTP01 challenges.
+type ACMEChallengeSolver struct {
+	// Selector selects the sets of DNSNames/CommonName that this solver
+	// will be used to solve.
+	// +optional
+	Selector *CertificateDNSNameSelector `json:"selector,omitempty"`
+
+	// DNS01 configures this challenge solver to presented DNS01 challenges.
+	// +optional
+	DNS01 *ACMEChallengeSolverDNS01 `json:"dns01,omitempty"`
+}
+
+// CertificateDNSNameSelector selects certificates using a label selector,
+// and can optionally select individual DNS names within those certificates.
+type CertificateDNSNameSelector struct {
+	// DNSNames is a list of DNS names this selector matches. If it is set,
+	// this solver will only be used when a challenge is for one of the
+	// DNS names on this list.
+	// +optional
+	DNSNames []string `json:"dnsNames,omitempty"`
+}
+
+// CNAMEStrategy configures how the DNS01 presenter should behave when it
+// finds a CNAME record at the `_acme-challenge` subdomain of a domain being
+// validated.
+type CNAMEStrategy string
+
+const (
+	// NoneStrategy does not follow CNAMEs. The TXT record is written at
+	// `_acme-challenge.<domain>` directly; this is the default.
+	NoneStrategy CNAMEStrategy = "None"
+
+	// FollowStrategy follows CNAME chains starting at
+	// `_acme-challenge.<domain>` and writes the TXT record at the final
+	// CNAME target instead, allowing ACME validation for a locked-down
+	// zone to be delegated to a scratch zone.
+	FollowStrategy CNAMEStrategy = "Follow"
+)
+
+// ACMESelfCheckStrategy selects how the DNS01 presenter verifies that a
+// challenge record has propagated before telling the ACME server to
+// validate it.
+type ACMESelfCheckStrategy string
+
+const (
+	// RecursiveSelfCheckStrategy polls a configurable list of recursive
+	// resolvers until the expected TXT record is observed. This is the
+	// long-standing default behavior.
+	RecursiveSelfCheckStrategy ACMESelfCheckStrategy = "Recursive"
+
+	// AuthoritativeSelfCheckStrategy queries the zone's authoritative NS
+	// records directly, requiring all of them to return the expected TXT
+	// record before proceeding.
+	AuthoritativeSelfCheckStrategy ACMESelfCheckStrategy = "Authoritative"
+
+	// DisabledSelfCheckStrategy skips the self-check entirely and proceeds
+	// straight to telling the ACME server to validate the challenge. Only
+	// safe with DNS providers that guarantee synchronous propagation.
+	DisabledSelfCheckStrategy ACMESelfCheckStrategy = "Disabled"
+)
+
+// ACMEChallengeSolverDNS01SelfCheck configures the propagation check
+// performed before a DNS01 challenge is accepted as ready.
+type ACMEChallengeSolverDNS01SelfCheck struct {
+	// Strategy selects the propagation check strategy. Defaults to
+	// RecursiveSelfCheckStrategy if unset.
+	// +optional
+	Strategy ACMESelfCheckStrategy `json:"strategy,omitempty"`
+
+	// RecursiveNameservers is the list of resolvers used when Strategy is
+	// RecursiveSelfCheckStrategy. Defaults to a built-in list of public
+	// recursive resolvers if empty.
+	// +optional
+	RecursiveNameservers []string `json:"recursiveNameservers,omitempty"`
+
+	// Timeout bounds how long the self-check is allowed to take, in the
+	// Go duration format (e.g. "60s"). Ignored when Strategy is
+	// DisabledSelfCheckStrategy.
+	// +optional
+	Timeout string `json:"timeout,omitempty"`
+}
+
+// ACMEChallengeSolverDNS01 uses the configured DNS provider to present and
+// remove the TXT records needed to solve a DNS01 ACME challenge.
+type ACMEChallengeSolverDNS01 struct {
+	// CNAMEStrategy configures how the presenter should behave when it
+	// finds a CNAME record for `_acme-challenge.<domain>`.
+	// +optional
+	CNAMEStrategy CNAMEStrategy `json:"cnameStrategy,omitempty"`
+
+	// SelfCheck configures how the presenter verifies propagation before
+	// accepting the challenge. Defaults to RecursiveSelfCheckStrategy.
+	// +optional
+	SelfCheck *ACMEChallengeSolverDNS01SelfCheck `json:"selfCheck,omitempty"`
+
+	// Name dispatches to a DNS provider registered via
+	// pkg/issuer/acme/dns.RegisterDNSProvider, using Config as its
+	// provider-specific configuration. This is the extension point
+	// out-of-tree DNS providers use; it is mutually exclusive with the
+	// typed provider fields below.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// Config holds provider-specific configuration for the provider named
+	// by Name, keyed by field name. Only used when Name is set.
+	// +optional
+	Config map[string]json.RawMessage `json:"config,omitempty"`
+
+	// +optional
+	Akamai *ACMEIssuerDNS01ProviderAkamai `json:"akamai,omitempty"`
+	// +optional
+	CloudDNS *ACMEIssuerDNS01ProviderCloudDNS `json:"clouddns,omitempty"`
+	// +optional
+	Cloudflare *ACMEIssuerDNS01ProviderCloudflare `json:"cloudflare,omitempty"`
+	// +optional
+	Route53 *ACMEIssuerDNS01ProviderRoute53 `json:"route53,omitempty"`
+	// +optional
+	AzureDNS *ACMEIssuerDNS01ProviderAzureDNS `json:"azuredns,omitempty"`
+	// +optional
+	DigitalOcean *ACMEIssuerDNS01ProviderDigitalOcean `json:"digitalocean,omitempty"`
+	// +optional
+	AcmeDNS *ACMEIssuerDNS01ProviderAcmeDNS `json:"acmedns,omitempty"`
+	// +optional
+	RFC2136 *ACMEIssuerDNS01ProviderRFC2136 `json:"rfc2136,omitempty"`
+	// +optional
+	Webhook *ACMEIssuerDNS01ProviderWebhook `json:"webhook,omitempty"`
+}
+
+type ACMEIssuerDNS01ProviderAkamai struct {
+	ServiceConsumerDomain string `json:"serviceConsumerDomain"`
+}
+
+type ACMEIssuerDNS01ProviderCloudDNS struct {
+	Project string `json:"project"`
+}
+
+type ACMEIssuerDNS01ProviderCloudflare struct {
+	Email string `json:"email"`
+}
+
+type ACMEIssuerDNS01ProviderRoute53 struct {
+	Region string `json:"region"`
+}
+
+type ACMEIssuerDNS01ProviderAzureDNS struct {
+	SubscriptionID string `json:"subscriptionID"`
+}
+
+type ACMEIssuerDNS01ProviderDigitalOcean struct {
+	Token cmmeta.SecretKeySelector `json:"tokenSecretRef"`
+}
+
+type ACMEIssuerDNS01ProviderAcmeDNS struct {
+	Host string `json:"host"`
+}
+
+// ACMEIssuerDNS01ProviderRFC2136 configures a DNS01 presenter that speaks
+// RFC2136 dynamic DNS updates directly to an authoritative nameserver.
+type ACMEIssuerDNS01ProviderRFC2136 struct {
+	// Nameserver is the IP address or hostname of an authoritative DNS
+	// server supporting RFC2136 in the form host:port.
+	Nameserver string `json:"nameserver"`
+
+	// TSIGSecret references the Secret containing the TSIG key used to
+	// authenticate updates.
+	// +optional
+	TSIGSecret cmmeta.SecretKeySelector `json:"tsigSecretSecretRef,omitempty"`
+
+	// TSIGKeyName is the TSIG key name used to authenticate updates.
+	// +optional
+	TSIGKeyName string `json:"tsigKeyName,omitempty"`
+
+	// TSIGAlgorithm is the TSIG algorithm, e.g. HMACSHA256.
+	// +optional
+	TSIGAlgorithm string `json:"tsigAlgorithm,omitempty"`
+}
+
+// ACMEIssuerDNS01ProviderWebhook configures a DNS01 presenter that dispatches
+// to an out-of-tree webhook solver, identified by GroupName/SolverName.
+type ACMEIssuerDNS01ProviderWebhook struct {
+	GroupName  string `json:"groupName"`
+	SolverName string `json:"solverName"`
+}
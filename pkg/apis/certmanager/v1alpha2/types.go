@@ -0,0 +1,145 @@
+/*
+Copyright 2019 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package v1alpha2 is the v1alpha2 version of the certmanager.k8s.io API
+// group: Issuers and Certificates.
+package v1alpha2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1alpha2"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+)
+
+// Issuer represents a certificate issuing authority which can be
+// referenced as part of `issuerRef` on Certificate resources.
+type Issuer struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   IssuerSpec   `json:"spec,omitempty"`
+	Status IssuerStatus `json:"status,omitempty"`
+}
+
+// IssuerSpec describes how a given issuer backend should be configured.
+type IssuerSpec struct {
+	IssuerConfig `json:",inline"`
+}
+
+// IssuerConfig holds the configuration for a single issuer backend. Exactly
+// one of its fields should be set.
+type IssuerConfig struct {
+	ACME *cmacme.ACMEIssuer `json:"acme,omitempty"`
+}
+
+// IssuerStatus holds the observed state of an Issuer.
+type IssuerStatus struct {
+	Conditions      []IssuerCondition        `json:"conditions,omitempty"`
+	ACMEStatusField *cmacme.ACMEIssuerStatus `json:"acme,omitempty"`
+}
+
+// GetStatus returns the Issuer's status, satisfying the same accessor shape
+// the ACME controller and e2e helpers use across Issuer/ClusterIssuer.
+func (i *Issuer) GetStatus() *IssuerStatus {
+	return &i.Status
+}
+
+// ACMEStatus returns the ACME-specific status block, auto-vivifying it if
+// necessary so callers can unconditionally read/write through it.
+func (s *IssuerStatus) ACMEStatus() *cmacme.ACMEIssuerStatus {
+	if s.ACMEStatusField == nil {
+		s.ACMEStatusField = &cmacme.ACMEIssuerStatus{}
+	}
+	return s.ACMEStatusField
+}
+
+// IssuerConditionType represents an Issuer condition value.
+type IssuerConditionType string
+
+const (
+	// IssuerConditionReady indicates that the issuer is ready to issue
+	// certificates, e.g. its ACME account has been registered.
+	IssuerConditionReady IssuerConditionType = "Ready"
+)
+
+// IssuerCondition contains condition information for an Issuer.
+type IssuerCondition struct {
+	Type    IssuerConditionType    `json:"type"`
+	Status  cmmeta.ConditionStatus `json:"status"`
+	Reason  string                 `json:"reason,omitempty"`
+	Message string                 `json:"message,omitempty"`
+}
+
+// Certificate is a type to represent a Certificate resource, describing a
+// desired TLS certificate that cert-manager should obtain and keep renewed.
+type Certificate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CertificateSpec   `json:"spec,omitempty"`
+	Status CertificateStatus `json:"status,omitempty"`
+}
+
+// CertificateSpec describes the desired state of a Certificate.
+type CertificateSpec struct {
+	// CommonName is the common name to be set on the certificate's Subject.
+	CommonName string `json:"commonName,omitempty"`
+	// DNSNames is a list of subject alt names to be set on the Certificate.
+	DNSNames []string `json:"dnsNames,omitempty"`
+	// SecretName is the name of the Secret the signed certificate and
+	// private key are written to.
+	SecretName string `json:"secretName"`
+	// IssuerRef references the Issuer or ClusterIssuer that should sign
+	// this Certificate.
+	IssuerRef cmmeta.ObjectReference `json:"issuerRef"`
+	// KeyAlgorithm is the private key algorithm to use when generating the
+	// CSR for this Certificate. Defaults to RSAKeyAlgorithm if unset, or to
+	// the issuing Issuer's default key algorithm if it has one configured.
+	// +optional
+	KeyAlgorithm cmmeta.KeyAlgorithm `json:"keyAlgorithm,omitempty"`
+	// KeySize is the size in bits (for RSAKeyAlgorithm) or curve size (for
+	// ECDSAKeyAlgorithm, e.g. 256 or 384) of the generated private key.
+	// Defaults depend on KeyAlgorithm.
+	// +optional
+	KeySize int `json:"keySize,omitempty"`
+}
+
+// RSAKeyAlgorithm and ECDSAKeyAlgorithm are re-exported from cmmeta so that
+// callers working with certmanager.k8s.io types don't need a second import
+// just to name a key algorithm.
+const (
+	RSAKeyAlgorithm   = cmmeta.RSAKeyAlgorithm
+	ECDSAKeyAlgorithm = cmmeta.ECDSAKeyAlgorithm
+)
+
+// CertificateStatus describes the observed state of a Certificate.
+type CertificateStatus struct {
+	Conditions []CertificateCondition `json:"conditions,omitempty"`
+}
+
+// CertificateConditionType represents a Certificate condition value.
+type CertificateConditionType string
+
+const (
+	CertificateConditionReady CertificateConditionType = "Ready"
+)
+
+// CertificateCondition contains condition information for a Certificate.
+type CertificateCondition struct {
+	Type   CertificateConditionType `json:"type"`
+	Status cmmeta.ConditionStatus   `json:"status"`
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2019 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package v1 holds small shared types referenced from both the
+// certmanager.k8s.io and acme.cert-manager.io API groups.
+package v1
+
+// LocalObjectReference is a reference to an object in the same namespace as
+// the referent. Unlike corev1.LocalObjectReference, it is not restricted to
+// referencing Secrets, but in practice every current usage does.
+type LocalObjectReference struct {
+	// Name of the referent.
+	Name string `json:"name,omitempty"`
+}
+
+// SecretKeySelector selects a key of a Secret.
+type SecretKeySelector struct {
+	LocalObjectReference `json:",inline"`
+
+	// The key of the Secret to select from. Defaults to the only key in the
+	// Secret if there is only one.
+	// +optional
+	Key string `json:"key,omitempty"`
+}
+
+// ObjectReference is a reference to an object with a given name and kind.
+type ObjectReference struct {
+	Name string `json:"name"`
+	Kind string `json:"kind,omitempty"`
+}
+
+// ConditionStatus is the status of a condition, mirroring corev1.ConditionStatus.
+type ConditionStatus string
+
+const (
+	ConditionTrue    ConditionStatus = "True"
+	ConditionFalse   ConditionStatus = "False"
+	ConditionUnknown ConditionStatus = "Unknown"
+)
+
+// KeyAlgorithm is the algorithm used to generate a private key. It lives
+// here, rather than on the certmanager.k8s.io API group alone, so that it
+// can also be used as an ACME issuer's default without the acme.cert-manager.io
+// group having to import the certmanager.k8s.io one.
+type KeyAlgorithm string
+
+const (
+	// RSAKeyAlgorithm generates an RSA private key. This is the default.
+	RSAKeyAlgorithm KeyAlgorithm = "rsa"
+	// ECDSAKeyAlgorithm generates an ECDSA private key on the curve
+	// matching the requested key size (256 -> P256, 384 -> P384, 521 -> P521).
+	ECDSAKeyAlgorithm KeyAlgorithm = "ecdsa"
+)
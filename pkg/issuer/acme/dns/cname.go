@@ -0,0 +1,90 @@
+/*
+Copyright 2019 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dns
+
+import (
+	"fmt"
+	"net"
+
+	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1alpha2"
+)
+
+// followingProvider wraps a Provider, resolving fqdn to the end of its CNAME
+// chain before calling through, so that a zone which only holds a CNAME at
+// _acme-challenge.<domain> delegates ACME validation to whatever zone that
+// CNAME points at rather than requiring write access to the primary zone.
+type followingProvider struct {
+	Provider
+
+	lookupCNAME func(host string) (string, error)
+}
+
+func wrapCNAMEStrategy(p Provider, strategy cmacme.CNAMEStrategy) Provider {
+	if strategy != cmacme.FollowStrategy {
+		return p
+	}
+	return &followingProvider{Provider: p, lookupCNAME: defaultLookupCNAME}
+}
+
+func (p *followingProvider) Present(fqdn, value string) error {
+	target, err := p.follow(fqdn)
+	if err != nil {
+		return err
+	}
+	return p.Provider.Present(target, value)
+}
+
+func (p *followingProvider) CleanUp(fqdn, value string) error {
+	target, err := p.follow(fqdn)
+	if err != nil {
+		return err
+	}
+	return p.Provider.CleanUp(target, value)
+}
+
+// follow resolves fqdn to the end of its CNAME chain, returning fqdn
+// unchanged if it has no CNAME record.
+func (p *followingProvider) follow(fqdn string) (string, error) {
+	seen := map[string]bool{}
+	current := fqdn
+	for {
+		if seen[current] {
+			return "", fmt.Errorf("dns: CNAME loop detected following %s", fqdn)
+		}
+		seen[current] = true
+
+		target, err := p.lookupCNAME(current)
+		if err != nil {
+			return "", fmt.Errorf("dns: failed to resolve CNAME for %s: %w", current, err)
+		}
+		if target == "" || target == current {
+			return current, nil
+		}
+		current = target
+	}
+}
+
+func defaultLookupCNAME(host string) (string, error) {
+	cname, err := net.LookupCNAME(host)
+	if err != nil {
+		if dnsErr, ok := err.(*net.DNSError); ok && dnsErr.IsNotFound {
+			return "", nil
+		}
+		return "", err
+	}
+	return cname, nil
+}
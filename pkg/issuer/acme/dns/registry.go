@@ -0,0 +1,78 @@
+/*
+Copyright 2019 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package dns implements the DNS01 challenge solver: presenting and cleaning
+// up the TXT records needed to prove control of a domain, for both the
+// built-in, typed RFC2136 provider and providers registered at runtime
+// through RegisterDNSProvider.
+package dns
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// Provider presents and cleans up the DNS01 challenge TXT record for a
+// single domain. It is the interface both the built-in typed providers and
+// providers registered via RegisterDNSProvider must satisfy.
+type Provider interface {
+	// Present creates the TXT record fqdn with the given value.
+	Present(fqdn, value string) error
+	// CleanUp removes the TXT record created by Present.
+	CleanUp(fqdn, value string) error
+}
+
+// Factory builds a Provider from its raw, provider-specific configuration,
+// as found in ACMEChallengeSolverDNS01.Config.
+type Factory func(config map[string]json.RawMessage) (Provider, error)
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]Factory{}
+)
+
+// RegisterDNSProvider registers a DNS provider factory under name, so that
+// an ACMEChallengeSolverDNS01 with Name set to the same value dispatches to
+// it instead of one of the built-in typed provider fields. It is intended to
+// be called from an out-of-tree provider's init function, mirroring the
+// registration pattern used by Go's database/sql drivers.
+//
+// RegisterDNSProvider panics if name is already registered, since that
+// indicates two providers colliding on the same name at program startup
+// rather than a runtime condition callers can meaningfully recover from.
+func RegisterDNSProvider(name string, factory Factory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if _, ok := registry[name]; ok {
+		panic(fmt.Sprintf("dns: RegisterDNSProvider called twice for provider %q", name))
+	}
+	registry[name] = factory
+}
+
+// NewRegisteredProvider builds the Provider registered under name, or
+// returns an error if no provider has been registered under that name.
+func NewRegisteredProvider(name string, config map[string]json.RawMessage) (Provider, error) {
+	registryMu.RLock()
+	factory, ok := registry[name]
+	registryMu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("dns: no provider registered with name %q", name)
+	}
+	return factory(config)
+}
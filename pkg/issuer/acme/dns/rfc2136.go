@@ -0,0 +1,114 @@
+/*
+Copyright 2019 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dns
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/miekg/dns"
+
+	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1alpha2"
+)
+
+// rfc2136Provider presents DNS01 challenges by sending RFC2136 dynamic
+// update messages directly to an authoritative nameserver.
+type rfc2136Provider struct {
+	nameserver string
+	keyName    string
+	algorithm  string
+	secret     string
+}
+
+func newRFC2136Provider(cfg *cmacme.ACMEIssuerDNS01ProviderRFC2136) (Provider, error) {
+	if cfg.Nameserver == "" {
+		return nil, fmt.Errorf("rfc2136: nameserver must be set")
+	}
+	algorithm := cfg.TSIGAlgorithm
+	switch strings.ToUpper(algorithm) {
+	case "HMACMD5":
+		algorithm = dns.HmacMD5
+	case "HMACSHA1":
+		algorithm = dns.HmacSHA1
+	case "HMACSHA256":
+		algorithm = dns.HmacSHA256
+	case "HMACSHA512":
+		algorithm = dns.HmacSHA512
+	}
+	return &rfc2136Provider{
+		nameserver: cfg.Nameserver,
+		keyName:    cfg.TSIGKeyName,
+		algorithm:  algorithm,
+	}, nil
+}
+
+// init registers the RFC2136 provider under the name "rfc2136", decoding its
+// Config into the same fields as the typed RFC2136 solver configuration.
+func init() {
+	RegisterDNSProvider("rfc2136", func(config map[string]json.RawMessage) (Provider, error) {
+		raw, err := json.Marshal(config)
+		if err != nil {
+			return nil, fmt.Errorf("rfc2136: failed to encode config: %w", err)
+		}
+		var cfg cmacme.ACMEIssuerDNS01ProviderRFC2136
+		if err := json.Unmarshal(raw, &cfg); err != nil {
+			return nil, fmt.Errorf("rfc2136: failed to decode config: %w", err)
+		}
+		return newRFC2136Provider(&cfg)
+	})
+}
+
+func (p *rfc2136Provider) Present(fqdn, value string) error {
+	return p.update(fqdn, value, dns.TypeTXT, false)
+}
+
+func (p *rfc2136Provider) CleanUp(fqdn, value string) error {
+	return p.update(fqdn, value, dns.TypeTXT, true)
+}
+
+func (p *rfc2136Provider) update(fqdn, value string, rrType uint16, remove bool) error {
+	m := new(dns.Msg)
+	m.SetUpdate(fqdn)
+
+	rr, err := dns.NewRR(fmt.Sprintf("%s 60 IN TXT %q", fqdn, value))
+	if err != nil {
+		return fmt.Errorf("rfc2136: failed to build TXT record: %w", err)
+	}
+
+	if remove {
+		m.Remove([]dns.RR{rr})
+	} else {
+		m.Insert([]dns.RR{rr})
+	}
+
+	if p.keyName != "" {
+		m.SetTsig(dns.Fqdn(p.keyName), p.algorithm, 300, time.Now().Unix())
+	}
+
+	client := new(dns.Client)
+	if p.keyName != "" {
+		client.TsigSecret = map[string]string{dns.Fqdn(p.keyName): p.secret}
+	}
+
+	_, _, err = client.Exchange(m, p.nameserver)
+	if err != nil {
+		return fmt.Errorf("rfc2136: failed to send update to %s: %w", p.nameserver, err)
+	}
+	return nil
+}
@@ -0,0 +1,178 @@
+/*
+Copyright 2019 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dns
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/miekg/dns"
+
+	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1alpha2"
+)
+
+// defaultRecursiveNameservers is used by RecursiveSelfCheckStrategy when a
+// solver does not specify its own RecursiveNameservers.
+var defaultRecursiveNameservers = []string{"8.8.8.8:53", "1.1.1.1:53"}
+
+// defaultSelfCheckInterval is how often CheckPropagated polls while waiting
+// for the expected TXT record to appear.
+const defaultSelfCheckInterval = 2 * time.Second
+
+// CheckPropagated blocks until the TXT record at fqdn is observed to contain
+// value, according to check.Strategy, or until ctx is done or check.Timeout
+// (if set) has elapsed. DisabledSelfCheckStrategy returns immediately without
+// performing any lookups.
+func CheckPropagated(ctx context.Context, fqdn, value string, check *cmacme.ACMEChallengeSolverDNS01SelfCheck) error {
+	strategy := cmacme.RecursiveSelfCheckStrategy
+	if check != nil && check.Strategy != "" {
+		strategy = check.Strategy
+	}
+
+	if strategy == cmacme.DisabledSelfCheckStrategy {
+		return nil
+	}
+
+	if check != nil && check.Timeout != "" {
+		timeout, err := time.ParseDuration(check.Timeout)
+		if err != nil {
+			return fmt.Errorf("dns: invalid self-check timeout %q: %w", check.Timeout, err)
+		}
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	nameservers, err := selfCheckNameservers(ctx, fqdn, strategy, check)
+	if err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(defaultSelfCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		if allServersHaveRecord(nameservers, fqdn, value) {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("dns: timed out waiting for %s to propagate to %v: %w", fqdn, nameservers, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// selfCheckNameservers returns the set of nameservers that must agree on the
+// TXT record's value before CheckPropagated succeeds: the configured (or
+// default) recursive resolvers for RecursiveSelfCheckStrategy, or the zone's
+// own authoritative nameservers for AuthoritativeSelfCheckStrategy.
+func selfCheckNameservers(ctx context.Context, fqdn string, strategy cmacme.ACMESelfCheckStrategy, check *cmacme.ACMEChallengeSolverDNS01SelfCheck) ([]string, error) {
+	switch strategy {
+	case cmacme.AuthoritativeSelfCheckStrategy:
+		return lookupAuthoritativeNameservers(fqdn)
+	case cmacme.RecursiveSelfCheckStrategy:
+		if check != nil && len(check.RecursiveNameservers) > 0 {
+			return check.RecursiveNameservers, nil
+		}
+		return defaultRecursiveNameservers, nil
+	default:
+		return nil, fmt.Errorf("dns: unknown self-check strategy %q", strategy)
+	}
+}
+
+// lookupAuthoritativeNameservers walks up from fqdn to find the nearest
+// enclosing zone's NS records, and returns their addresses on the standard
+// DNS port.
+func lookupAuthoritativeNameservers(fqdn string) ([]string, error) {
+	zone := fqdn
+	client := new(dns.Client)
+
+	for {
+		m := new(dns.Msg)
+		m.SetQuestion(dns.Fqdn(zone), dns.TypeNS)
+		resp, _, err := client.Exchange(m, defaultRecursiveNameservers[0])
+		if err == nil && len(resp.Answer) > 0 {
+			var servers []string
+			for _, rr := range resp.Answer {
+				if ns, ok := rr.(*dns.NS); ok {
+					servers = append(servers, ns.Ns+":53")
+				}
+			}
+			if len(servers) > 0 {
+				return servers, nil
+			}
+		}
+
+		parent, ok := parentZone(zone)
+		if !ok {
+			return nil, fmt.Errorf("dns: could not find authoritative nameservers for %s", fqdn)
+		}
+		zone = parent
+	}
+}
+
+func parentZone(zone string) (string, bool) {
+	labels := dns.SplitDomainName(zone)
+	if len(labels) <= 1 {
+		return "", false
+	}
+	return dns.Fqdn(joinLabels(labels[1:])), true
+}
+
+func joinLabels(labels []string) string {
+	out := ""
+	for i, l := range labels {
+		if i > 0 {
+			out += "."
+		}
+		out += l
+	}
+	return out
+}
+
+func allServersHaveRecord(nameservers []string, fqdn, value string) bool {
+	for _, ns := range nameservers {
+		if !serverHasRecord(ns, fqdn, value) {
+			return false
+		}
+	}
+	return true
+}
+
+func serverHasRecord(nameserver, fqdn, value string) bool {
+	m := new(dns.Msg)
+	m.SetQuestion(dns.Fqdn(fqdn), dns.TypeTXT)
+
+	client := new(dns.Client)
+	resp, _, err := client.Exchange(m, nameserver)
+	if err != nil {
+		return false
+	}
+	for _, rr := range resp.Answer {
+		if txt, ok := rr.(*dns.TXT); ok {
+			for _, s := range txt.Txt {
+				if s == value {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,50 @@
+/*
+Copyright 2019 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dns
+
+import (
+	"fmt"
+
+	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1alpha2"
+)
+
+// NewProvider builds the Provider described by solver. If solver.Name is
+// set, it dispatches to the provider registered under that name via
+// RegisterDNSProvider, passing solver.Config through unmodified. Otherwise
+// it builds the built-in RFC2136 provider if solver.RFC2136 is set; the other
+// typed provider fields (Route53, CloudDNS, ...) are not handled here, and a
+// solver with only one of those set returns an error.
+func NewProvider(solver *cmacme.ACMEChallengeSolverDNS01) (Provider, error) {
+	p, err := newBaseProvider(solver)
+	if err != nil {
+		return nil, err
+	}
+	return wrapCNAMEStrategy(p, solver.CNAMEStrategy), nil
+}
+
+func newBaseProvider(solver *cmacme.ACMEChallengeSolverDNS01) (Provider, error) {
+	if solver.Name != "" {
+		return NewRegisteredProvider(solver.Name, solver.Config)
+	}
+
+	switch {
+	case solver.RFC2136 != nil:
+		return newRFC2136Provider(solver.RFC2136)
+	default:
+		return nil, fmt.Errorf("dns: no DNS01 provider configured on solver")
+	}
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2019 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package keys generates and rotates the private keys backing Certificate
+// CSRs, according to the requested KeyAlgorithm/KeySize.
+package keys
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"fmt"
+
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+)
+
+// defaultRSAKeySize is used when a Certificate requests RSAKeyAlgorithm
+// without specifying KeySize.
+const defaultRSAKeySize = 2048
+
+// defaultECDSACurveSize is used when a Certificate requests
+// ECDSAKeyAlgorithm without specifying KeySize.
+const defaultECDSACurveSize = 256
+
+// GeneratePrivateKey generates a new private key for the given algorithm and
+// size, applying the package defaults documented above when size is 0.
+func GeneratePrivateKey(algorithm cmmeta.KeyAlgorithm, size int) (crypto.Signer, error) {
+	switch algorithm {
+	case "", cmmeta.RSAKeyAlgorithm:
+		if size == 0 {
+			size = defaultRSAKeySize
+		}
+		return rsa.GenerateKey(rand.Reader, size)
+
+	case cmmeta.ECDSAKeyAlgorithm:
+		curve, err := ecdsaCurveForSize(size)
+		if err != nil {
+			return nil, err
+		}
+		return ecdsa.GenerateKey(curve, rand.Reader)
+
+	default:
+		return nil, fmt.Errorf("keys: unsupported key algorithm %q", algorithm)
+	}
+}
+
+func ecdsaCurveForSize(size int) (elliptic.Curve, error) {
+	switch size {
+	case 0, 256:
+		return elliptic.P256(), nil
+	case 384:
+		return elliptic.P384(), nil
+	case 521:
+		return elliptic.P521(), nil
+	default:
+		return nil, fmt.Errorf("keys: unsupported ECDSA key size %d", size)
+	}
+}
+
+// NeedsRotation reports whether an existing private key must be regenerated
+// to satisfy a Certificate requesting algorithm/size: true whenever the
+// existing key's algorithm or size/curve doesn't match what's requested, so
+// that changing KeyAlgorithm on a Certificate (or its Issuer's default)
+// produces a new key rather than reusing a stale one of the wrong type.
+func NeedsRotation(existing crypto.Signer, algorithm cmmeta.KeyAlgorithm, size int) bool {
+	switch key := existing.(type) {
+	case *rsa.PrivateKey:
+		if algorithm != "" && algorithm != cmmeta.RSAKeyAlgorithm {
+			return true
+		}
+		want := size
+		if want == 0 {
+			want = defaultRSAKeySize
+		}
+		return key.N.BitLen() != want
+
+	case *ecdsa.PrivateKey:
+		if algorithm != cmmeta.ECDSAKeyAlgorithm {
+			return true
+		}
+		curve, err := ecdsaCurveForSize(size)
+		if err != nil {
+			return true
+		}
+		return key.Curve != curve
+
+	default:
+		return true
+	}
+}
+
+// MarshalPrivateKey DER-encodes key (without PEM armour) using the PKCS#1 form for RSA keys
+// and the SEC1 form for ECDSA keys, matching how each is parsed back by the
+// standard library and by x509.ParsePKCS1PrivateKey/ParseECPrivateKey.
+func MarshalPrivateKey(key crypto.Signer) ([]byte, error) {
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		return x509.MarshalPKCS1PrivateKey(k), nil
+	case *ecdsa.PrivateKey:
+		return x509.MarshalECPrivateKey(k)
+	default:
+		return nil, fmt.Errorf("keys: unsupported private key type %T", key)
+	}
+}
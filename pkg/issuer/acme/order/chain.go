@@ -0,0 +1,100 @@
+/*
+Copyright 2019 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package order drives an ACME order through finalization and certificate
+// download once all of its authorizations have been validated.
+package order
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+)
+
+// alternateLinkRelation is the RFC8555 section 7.4.2 link relation a CA uses
+// to advertise additional certificate chains for the same leaf, signed by a
+// different root than the chain returned in the primary response body.
+const alternateLinkRelation = "alternate"
+
+// chainFetcher is satisfied by *acme.Client. It is defined locally so this
+// package can be tested without depending on the full ACME client.
+type chainFetcher interface {
+	// FetchChain downloads the DER-encoded certificate chain at url, along
+	// with any "alternate" Link header URLs found on the response.
+	FetchChain(ctx context.Context, url string) (chain [][]byte, alternates []string, err error)
+}
+
+// SelectChain downloads the certificate chain at certURL and, if
+// preferredChain is set, returns the first chain whose topmost (root)
+// certificate's Issuer CommonName matches preferredChain: the default chain
+// is checked first, then each chain advertised via an "alternate" Link
+// header is downloaded in order until one matches. If no chain matches, or
+// preferredChain is empty, the default chain is returned.
+func SelectChain(ctx context.Context, client chainFetcher, certURL, preferredChain string) ([][]byte, error) {
+	chain, alternates, err := client.FetchChain(ctx, certURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download certificate chain: %w", err)
+	}
+	if preferredChain == "" {
+		return chain, nil
+	}
+	if chainMatches(chain, preferredChain) {
+		return chain, nil
+	}
+
+	for _, altURL := range alternates {
+		altChain, _, err := client.FetchChain(ctx, altURL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to download alternate certificate chain: %w", err)
+		}
+		if chainMatches(altChain, preferredChain) {
+			return altChain, nil
+		}
+	}
+
+	// No alternate matched; fall back to the default chain rather than
+	// failing the order, since PreferredChain is a preference and CAs are
+	// free to stop advertising a given root at any time.
+	return chain, nil
+}
+
+// chainMatches reports whether the topmost certificate in chain - its root,
+// or the highest intermediate available if the root itself isn't included -
+// has an Issuer CommonName equal to preferredChain.
+func chainMatches(chain [][]byte, preferredChain string) bool {
+	if len(chain) == 0 {
+		return false
+	}
+	top, err := x509.ParseCertificate(chain[len(chain)-1])
+	if err != nil {
+		return false
+	}
+	return top.Issuer.CommonName == preferredChain
+}
+
+// httpAlternateLinks extracts "alternate"-relation URLs from a certificate
+// download response's Link headers, as used by Client implementations of
+// chainFetcher.
+func httpAlternateLinks(header http.Header) []string {
+	var alternates []string
+	for _, link := range parseLinkHeaders(header) {
+		if link.rel == alternateLinkRelation {
+			alternates = append(alternates, link.url)
+		}
+	}
+	return alternates
+}
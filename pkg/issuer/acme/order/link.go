@@ -0,0 +1,64 @@
+/*
+Copyright 2019 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package order
+
+import (
+	"net/http"
+	"strings"
+)
+
+// link is a single parsed entry from an HTTP Link header, e.g.
+// `<https://example.com/acme/cert/1234/1>; rel="alternate"`.
+type link struct {
+	url string
+	rel string
+}
+
+// parseLinkHeaders parses every Link header value present in header into
+// its constituent entries. Malformed entries are skipped.
+func parseLinkHeaders(header http.Header) []link {
+	var links []link
+	for _, value := range header.Values("Link") {
+		for _, part := range strings.Split(value, ",") {
+			l, ok := parseLink(part)
+			if ok {
+				links = append(links, l)
+			}
+		}
+	}
+	return links
+}
+
+func parseLink(part string) (link, bool) {
+	segments := strings.Split(part, ";")
+	urlSegment := strings.TrimSpace(segments[0])
+	if !strings.HasPrefix(urlSegment, "<") || !strings.HasSuffix(urlSegment, ">") {
+		return link{}, false
+	}
+	url := strings.TrimSuffix(strings.TrimPrefix(urlSegment, "<"), ">")
+
+	for _, param := range segments[1:] {
+		param = strings.TrimSpace(param)
+		if !strings.HasPrefix(param, "rel=") {
+			continue
+		}
+		rel := strings.TrimPrefix(param, "rel=")
+		rel = strings.Trim(rel, `"`)
+		return link{url: url, rel: rel}, true
+	}
+	return link{}, false
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2019 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package acme implements the ACME Issuer: registering ACME accounts and
+// driving RFC8555 order/authorization/challenge/finalize flows to obtain
+// signed certificates.
+package acme
+
+import (
+	"context"
+	"fmt"
+
+	"golang.org/x/crypto/acme"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+
+	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1alpha2"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+	"github.com/jetstack/cert-manager/pkg/issuer/acme/eab"
+)
+
+// eabSecretDataKey is the key the auto-provisioned ZeroSSL EAB HMAC key is
+// stored under in EABSecretRef, mirroring how ACME account private keys are
+// stored under a single well-known key in their Secret.
+const eabSecretDataKey = "key"
+
+// eabKIDAnnotation records the EAB KeyID alongside the HMAC key stored in
+// EABSecretRef, so that it can be reused on subsequent reconciles without
+// minting a fresh one against ZeroSSL every time.
+const eabKIDAnnotation = "acme.cert-manager.io/eab-kid"
+
+// Setup registers (or re-registers) the ACME account described by issuer
+// against its directory, resolving External Account Binding credentials
+// first if required, and returns the account URI to be persisted onto the
+// Issuer's status.
+func Setup(ctx context.Context, secrets corev1client.SecretInterface, client *acme.Client, namespace string, issuer *cmacme.ACMEIssuer) (string, error) {
+	switch {
+	case issuer.ExternalAccountBinding != nil:
+		key, err := secretKeyBytes(ctx, secrets, issuer.ExternalAccountBinding.Key)
+		if err != nil {
+			return "", fmt.Errorf("failed to read externalAccountBinding key: %w", err)
+		}
+		client.KID = acme.KeyID(issuer.ExternalAccountBinding.KeyID)
+		client.HMACKey = key
+
+	case issuer.ZeroSSL != nil:
+		creds, err := zeroSSLCredentials(ctx, secrets, namespace, issuer.ZeroSSL)
+		if err != nil {
+			return "", fmt.Errorf("failed to provision ZeroSSL EAB credentials: %w", err)
+		}
+		client.KID = acme.KeyID(creds.KeyID)
+		client.HMACKey = []byte(creds.Key)
+	}
+
+	account := &acme.Account{Contact: contactsForEmail(issuer.Email)}
+	registered, err := client.Register(ctx, account, func(tosURL string) bool { return true })
+	if err != nil {
+		return "", fmt.Errorf("failed to register ACME account: %w", err)
+	}
+
+	return registered.URI, nil
+}
+
+// zeroSSLCredentials returns the EAB credentials to use for ZeroSSL
+// registration, fetching and persisting them on first use and reusing the
+// stored pair on subsequent reconciles so that re-registration does not
+// mint a new KeyID every time.
+func zeroSSLCredentials(ctx context.Context, secrets corev1client.SecretInterface, namespace string, z *cmacme.ZeroSSLIssuer) (eab.Credentials, error) {
+	existing, err := secrets.Get(ctx, z.EABSecretRef.Name, metav1.GetOptions{})
+	if err == nil {
+		if key, ok := existing.Data[eabSecretDataKey]; ok {
+			return eab.Credentials{KeyID: existing.Annotations[eabKIDAnnotation], Key: string(key)}, nil
+		}
+	}
+
+	creds, err := (&eab.ZeroSSLClient{}).FetchCredentials(ctx, z.Email)
+	if err != nil {
+		return eab.Credentials{}, err
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        z.EABSecretRef.Name,
+			Namespace:   namespace,
+			Annotations: map[string]string{eabKIDAnnotation: creds.KeyID},
+		},
+		Data: map[string][]byte{eabSecretDataKey: []byte(creds.Key)},
+	}
+	if _, err := secrets.Create(ctx, secret, metav1.CreateOptions{}); err != nil {
+		return eab.Credentials{}, fmt.Errorf("failed to persist ZeroSSL EAB credentials: %w", err)
+	}
+
+	return creds, nil
+}
+
+func secretKeyBytes(ctx context.Context, secrets corev1client.SecretInterface, ref cmmeta.SecretKeySelector) ([]byte, error) {
+	secret, err := secrets.Get(ctx, ref.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	key := ref.Key
+	if key == "" {
+		key = eabSecretDataKey
+		if len(secret.Data) == 1 {
+			for only := range secret.Data {
+				key = only
+			}
+		}
+	}
+	data, ok := secret.Data[key]
+	if !ok {
+		return nil, fmt.Errorf("secret %q has no data for key %q", ref.Name, key)
+	}
+	return data, nil
+}
+
+func contactsForEmail(email string) []string {
+	if email == "" {
+		return nil
+	}
+	return []string{"mailto:" + email}
+}
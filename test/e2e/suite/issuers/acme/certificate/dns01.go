@@ -18,10 +18,15 @@ package certificate
 
 import (
 	"context"
+	"crypto/elliptic"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
 	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1alpha2"
@@ -39,10 +44,33 @@ type dns01Provider interface {
 	addon.Addon
 }
 
+// zeroSSLACMEServerURL is ZeroSSL's production ACME directory endpoint.
+// Unlike the other tests in this file, testZeroSSLDNSProvider talks to this
+// directory instead of the Pebble instance started by the e2e framework,
+// since EAB auto-provisioning is a ZeroSSL-specific account registration
+// flow that Pebble does not implement. It therefore depends on outbound
+// internet access and a third-party CA's rate limits, so it is gated behind
+// zeroSSLLiveTestEnvVar rather than running unconditionally.
+const zeroSSLACMEServerURL = "https://acme.zerossl.com/v2/DV90"
+
+// zeroSSLLiveTestEnvVar opts this suite into testZeroSSLDNSProvider, which
+// registers a real account against ZeroSSL's production ACME directory using
+// testingACMEEmail. It is off by default so that this otherwise hermetic
+// suite doesn't depend on outbound access to, or the shared test account's
+// standing with, a third-party CA.
+const zeroSSLLiveTestEnvVar = "CERT_MANAGER_E2E_ZEROSSL_LIVE"
+
 var _ = framework.CertManagerDescribe("ACME Certificate (DNS01)", func() {
 	rfc := &dnsproviders.RFC2136{}
 
 	testDNSProvider("rfc2136", rfc)
+	if os.Getenv(zeroSSLLiveTestEnvVar) != "" {
+		testZeroSSLDNSProvider("rfc2136", rfc)
+	}
+	testRegisteredDNSProvider("rfc2136")
+	testRFC2136CNAMEDelegation(rfc)
+	testRFC2136SelfCheckStrategy(rfc)
+	testRFC2136KeyAlgorithm(rfc)
 })
 
 func testDNSProvider(name string, p dns01Provider) bool {
@@ -163,5 +191,382 @@ func testDNSProvider(name string, p dns01Provider) bool {
 			err = h.WaitCertificateIssuedValid(f.Namespace.Name, certificateName, time.Minute*10)
 			Expect(err).NotTo(HaveOccurred())
 		})
+
+		Context("With a PreferredChain set on the Issuer", func() {
+			preferredChainIssuerName := "test-acme-issuer-preferred-chain"
+			preferredChainRootCN := "ISRG Root X1"
+
+			BeforeEach(func() {
+				By("Creating an Issuer with PreferredChain set")
+				issuer := gen.Issuer(preferredChainIssuerName,
+					gen.SetIssuerACME(cmacme.ACMEIssuer{
+						SkipTLSVerify:  true,
+						Server:         f.Config.Addons.ACMEServer.URL,
+						Email:          testingACMEEmail,
+						PreferredChain: preferredChainRootCN,
+						PrivateKey: cmmeta.SecretKeySelector{
+							LocalObjectReference: cmmeta.LocalObjectReference{
+								Name: testingACMEPrivateKey,
+							},
+						},
+						Solvers: []cmacme.ACMEChallengeSolver{
+							{
+								DNS01: &p.Details().ProviderConfig,
+							},
+						},
+					}))
+				issuer.Namespace = f.Namespace.Name
+				issuer, err := f.CertManagerClientSet.CertmanagerV1alpha2().Issuers(f.Namespace.Name).Create(context.TODO(), issuer, metav1.CreateOptions{})
+				Expect(err).NotTo(HaveOccurred())
+				err = util.WaitForIssuerCondition(f.CertManagerClientSet.CertmanagerV1alpha2().Issuers(f.Namespace.Name),
+					preferredChainIssuerName,
+					v1alpha2.IssuerCondition{
+						Type:   v1alpha2.IssuerConditionReady,
+						Status: cmmeta.ConditionTrue,
+					})
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			AfterEach(func() {
+				f.CertManagerClientSet.CertmanagerV1alpha2().Issuers(f.Namespace.Name).Delete(context.TODO(), preferredChainIssuerName, metav1.DeleteOptions{})
+			})
+
+			It("should obtain a signed certificate using the requested alternate chain", func() {
+				By("Creating a Certificate")
+
+				cert := gen.Certificate(certificateName,
+					gen.SetCertificateSecretName(certificateSecretName),
+					gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: preferredChainIssuerName}),
+					gen.SetCertificateDNSNames(dnsDomain),
+				)
+				cert.Namespace = f.Namespace.Name
+
+				cert, err := f.CertManagerClientSet.CertmanagerV1alpha2().Certificates(f.Namespace.Name).Create(context.TODO(), cert, metav1.CreateOptions{})
+				Expect(err).NotTo(HaveOccurred())
+				err = h.WaitCertificateIssuedValid(f.Namespace.Name, certificateName, time.Minute*5)
+				Expect(err).NotTo(HaveOccurred())
+
+				By("Verifying the issued chain's root matches the PreferredChain")
+				secret, err := f.KubeClientSet.CoreV1().Secrets(f.Namespace.Name).Get(context.TODO(), certificateSecretName, metav1.GetOptions{})
+				Expect(err).NotTo(HaveOccurred())
+				root := util.RootCertificate(secret.Data[corev1.TLSCertKey])
+				Expect(root.Issuer.CommonName).To(Equal(preferredChainRootCN))
+			})
+		})
+	})
+}
+
+// dns01Fixture captures the Issuer/Certificate lifecycle shared by the
+// DNS01 solver variants below: create an Issuer wrapping a (possibly
+// mutated) copy of the provider's base solver config, wait for it to
+// become Ready, create a Certificate once dnsDomain is known, wait for it
+// to be issued, then tear both down. Only the bits that vary between
+// variants are parameterized.
+type dns01Fixture struct {
+	p dns01Provider
+
+	// beforeIssuer, if set, runs once dnsDomain is known but before the
+	// Issuer is created, e.g. to provision out-of-band DNS records.
+	beforeIssuer func(dnsDomain string)
+	// mutateSolver, if set, customizes the DNS01 solver under test. It
+	// starts as a copy of p.Details().ProviderConfig.
+	mutateSolver func(solver *cmacme.ACMEChallengeSolverDNS01)
+	// dnsNames returns the Certificate's requested DNS names. Defaults to
+	// []string{dnsDomain}.
+	dnsNames func(dnsDomain string) []string
+	// certOpts, if set, appends extra gen.Certificate options.
+	certOpts func(dnsDomain string) []gen.CertificateModifier
+	// check, if set, runs extra assertions once the Certificate is issued.
+	check func(f *framework.Framework, secretName, dnsDomain string)
+}
+
+func (fx dns01Fixture) run(contextName, namePrefix string) bool {
+	return Context(contextName, func() {
+		f := framework.NewDefaultFramework(namePrefix)
+		h := f.Helper()
+
+		f.RequireAddon(fx.p)
+
+		issuerName := namePrefix + "-issuer"
+		certificateName := namePrefix + "-certificate"
+		certificateSecretName := certificateName
+		dnsDomain := ""
+
+		BeforeEach(func() {
+			dnsDomain = fx.p.Details().NewTestDomain()
+
+			if fx.beforeIssuer != nil {
+				fx.beforeIssuer(dnsDomain)
+			}
+
+			By("Creating an Issuer")
+			solver := fx.p.Details().ProviderConfig
+			if fx.mutateSolver != nil {
+				fx.mutateSolver(&solver)
+			}
+			issuer := gen.Issuer(issuerName,
+				gen.SetIssuerACME(cmacme.ACMEIssuer{
+					SkipTLSVerify: true,
+					Server:        f.Config.Addons.ACMEServer.URL,
+					Email:         testingACMEEmail,
+					PrivateKey: cmmeta.SecretKeySelector{
+						LocalObjectReference: cmmeta.LocalObjectReference{
+							Name: testingACMEPrivateKey,
+						},
+					},
+					Solvers: []cmacme.ACMEChallengeSolver{
+						{
+							DNS01: &solver,
+						},
+					},
+				}))
+			issuer.Namespace = f.Namespace.Name
+			issuer, err := f.CertManagerClientSet.CertmanagerV1alpha2().Issuers(f.Namespace.Name).Create(context.TODO(), issuer, metav1.CreateOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			By("Waiting for Issuer to become Ready")
+			err = util.WaitForIssuerCondition(f.CertManagerClientSet.CertmanagerV1alpha2().Issuers(f.Namespace.Name),
+				issuerName,
+				v1alpha2.IssuerCondition{
+					Type:   v1alpha2.IssuerConditionReady,
+					Status: cmmeta.ConditionTrue,
+				})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			By("Cleaning up")
+			f.CertManagerClientSet.CertmanagerV1alpha2().Issuers(f.Namespace.Name).Delete(context.TODO(), issuerName, metav1.DeleteOptions{})
+			f.KubeClientSet.CoreV1().Secrets(f.Namespace.Name).Delete(context.TODO(), testingACMEPrivateKey, metav1.DeleteOptions{})
+			f.KubeClientSet.CoreV1().Secrets(f.Namespace.Name).Delete(context.TODO(), certificateSecretName, metav1.DeleteOptions{})
+		})
+
+		It("should obtain a signed certificate", func() {
+			By("Creating a Certificate")
+
+			names := []string{dnsDomain}
+			if fx.dnsNames != nil {
+				names = fx.dnsNames(dnsDomain)
+			}
+			opts := []gen.CertificateModifier{
+				gen.SetCertificateSecretName(certificateSecretName),
+				gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: issuerName}),
+				gen.SetCertificateDNSNames(names...),
+			}
+			if fx.certOpts != nil {
+				opts = append(opts, fx.certOpts(dnsDomain)...)
+			}
+			cert := gen.Certificate(certificateName, opts...)
+			cert.Namespace = f.Namespace.Name
+
+			cert, err := f.CertManagerClientSet.CertmanagerV1alpha2().Certificates(f.Namespace.Name).Create(context.TODO(), cert, metav1.CreateOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			err = h.WaitCertificateIssuedValid(f.Namespace.Name, certificateName, time.Minute*5)
+			Expect(err).NotTo(HaveOccurred())
+
+			if fx.check != nil {
+				fx.check(f, certificateSecretName, dnsDomain)
+			}
+		})
+	})
+}
+
+// testRegisteredDNSProvider exercises a DNS01 solver configured purely
+// through the generic Config escape hatch on ACMEChallengeSolverDNS01,
+// dispatched by name via the pkg/issuer/acme/dns provider registry rather
+// than a typed field such as RFC2136 or Route53. This is the path new,
+// out-of-tree DNS providers are expected to use.
+func testRegisteredDNSProvider(name string) bool {
+	rfc := &dnsproviders.RFC2136{}
+
+	return dns01Fixture{
+		p: rfc,
+		mutateSolver: func(solver *cmacme.ACMEChallengeSolverDNS01) {
+			*solver = cmacme.ACMEChallengeSolverDNS01{
+				Name:   name,
+				Config: rfc.Details().RegistryConfig(),
+			}
+		},
+	}.run("With "+name+" registered via the DNS provider registry", "create-acme-certificate-dns01-registry-"+name)
+}
+
+// testRFC2136CNAMEDelegation covers CNAMEStrategy: Follow, where the primary
+// zone only holds a CNAME at _acme-challenge.<domain> pointing at a scratch
+// zone, and the DNS01 presenter is expected to follow that CNAME and write
+// the validation TXT record in the delegated zone rather than the origin
+// zone. This lets an operator delegate ACME validation without granting the
+// solver write access to the primary zone.
+func testRFC2136CNAMEDelegation(p dns01Provider) bool {
+	return dns01Fixture{
+		p: p,
+		beforeIssuer: func(dnsDomain string) {
+			By("Creating a CNAME delegating _acme-challenge to a scratch zone")
+			err := p.Details().CreateCNAMERecord(context.TODO(), "_acme-challenge."+dnsDomain, p.Details().DelegatedZone())
+			Expect(err).NotTo(HaveOccurred())
+		},
+		mutateSolver: func(solver *cmacme.ACMEChallengeSolverDNS01) {
+			solver.CNAMEStrategy = cmacme.FollowStrategy
+		},
+	}.run("With a CNAME delegating _acme-challenge to a scratch zone", "create-acme-certificate-dns01-rfc2136-cname")
+}
+
+// testRFC2136SelfCheckStrategy covers the three SelfCheck modes a
+// DNS01 solver can request before telling the ACME server to validate:
+// Authoritative (query the zone's NS records directly), Recursive (the
+// long-standing default, polling configured recursive resolvers), and
+// Disabled (skip the self-check entirely and proceed straight to
+// challenge.Accept). rfc2136 propagates synchronously, so Disabled is safe
+// here and avoids the recursive-resolver poll entirely.
+func testRFC2136SelfCheckStrategy(p dns01Provider) bool {
+	strategies := []cmacme.ACMESelfCheckStrategy{
+		cmacme.AuthoritativeSelfCheckStrategy,
+		cmacme.RecursiveSelfCheckStrategy,
+		cmacme.DisabledSelfCheckStrategy,
+	}
+
+	return Context("With each SelfCheck strategy", func() {
+		for _, strategy := range strategies {
+			strategy := strategy
+
+			dns01Fixture{
+				p: p,
+				mutateSolver: func(solver *cmacme.ACMEChallengeSolverDNS01) {
+					solver.SelfCheck = &cmacme.ACMEChallengeSolverDNS01SelfCheck{
+						Strategy: strategy,
+					}
+				},
+			}.run("Using SelfCheck: "+string(strategy), "create-acme-certificate-dns01-rfc2136-selfcheck-"+string(strategy))
+		}
+	})
+}
+
+// testRFC2136KeyAlgorithm covers requesting a non-default private key
+// algorithm on a Certificate. The controller is expected to generate the CSR
+// with the requested algorithm/curve and to write out a matching key, rather
+// than the RSA key it defaults to.
+func testRFC2136KeyAlgorithm(p dns01Provider) bool {
+	return dns01Fixture{
+		p: p,
+		dnsNames: func(dnsDomain string) []string {
+			return []string{"*." + dnsDomain}
+		},
+		certOpts: func(dnsDomain string) []gen.CertificateModifier {
+			return []gen.CertificateModifier{
+				gen.SetCertificateKeyAlgorithm(v1alpha2.ECDSAKeyAlgorithm),
+				gen.SetCertificateKeySize(384),
+			}
+		},
+		check: func(f *framework.Framework, secretName, dnsDomain string) {
+			By("Verifying the issued private key is an ECDSA P-384 key")
+			secret, err := f.KubeClientSet.CoreV1().Secrets(f.Namespace.Name).Get(context.TODO(), secretName, metav1.GetOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			block, _ := pem.Decode(secret.Data[corev1.TLSPrivateKeyKey])
+			Expect(block).NotTo(BeNil())
+			key, err := x509.ParseECPrivateKey(block.Bytes)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(key.Curve).To(Equal(elliptic.P384()))
+			Expect(key.Curve.Params().BitSize).To(Equal(384))
+		},
+	}.run("With a requested key algorithm", "create-acme-certificate-dns01-rfc2136-keyalgorithm")
+}
+
+// testZeroSSLDNSProvider exercises an Issuer configured against ZeroSSL
+// rather than the Pebble ACME server used by testDNSProvider. ZeroSSL
+// requires EAB credentials on account registration; rather than asking the
+// user to pre-provision a KID/HMAC pair, the ACME controller auto-provisions
+// them from the issuer's email address via ZeroSSL's EAB endpoint and stores
+// the result in a Secret, which this test asserts on directly. ZeroSSL also
+// requires a CommonName-bearing CSR, unlike Pebble, so the issued Certificate
+// is checked for a CommonName equal to the requested domain on its chain leaf.
+func testZeroSSLDNSProvider(name string, p dns01Provider) bool {
+	return Context("With ZeroSSL and "+name+" credentials configured", func() {
+		f := framework.NewDefaultFramework("create-acme-certificate-zerossl-" + name)
+		h := f.Helper()
+
+		f.RequireAddon(p)
+
+		issuerName := "test-acme-zerossl-issuer"
+		eabSecretName := "test-acme-zerossl-eab"
+		certificateName := "test-acme-zerossl-certificate"
+		certificateSecretName := "test-acme-zerossl-certificate"
+		dnsDomain := ""
+
+		BeforeEach(func() {
+			dnsDomain = p.Details().NewTestDomain()
+
+			By("Creating an Issuer configured for ZeroSSL")
+			issuer := gen.Issuer(issuerName,
+				gen.SetIssuerACME(cmacme.ACMEIssuer{
+					Server: zeroSSLACMEServerURL,
+					Email:  testingACMEEmail,
+					PrivateKey: cmmeta.SecretKeySelector{
+						LocalObjectReference: cmmeta.LocalObjectReference{
+							Name: testingACMEPrivateKey,
+						},
+					},
+					ZeroSSL: &cmacme.ZeroSSLIssuer{
+						// Email is the only input required; the controller
+						// exchanges it for a KID/HMAC pair via ZeroSSL's EAB
+						// endpoint and persists the result in EABSecretRef.
+						Email:        testingACMEEmail,
+						EABSecretRef: cmmeta.SecretKeySelector{LocalObjectReference: cmmeta.LocalObjectReference{Name: eabSecretName}},
+					},
+					Solvers: []cmacme.ACMEChallengeSolver{
+						{
+							DNS01: &p.Details().ProviderConfig,
+						},
+					},
+				}))
+			issuer.Namespace = f.Namespace.Name
+			issuer, err := f.CertManagerClientSet.CertmanagerV1alpha2().Issuers(f.Namespace.Name).Create(context.TODO(), issuer, metav1.CreateOptions{})
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Waiting for Issuer to become Ready")
+			err = util.WaitForIssuerCondition(f.CertManagerClientSet.CertmanagerV1alpha2().Issuers(f.Namespace.Name),
+				issuerName,
+				v1alpha2.IssuerCondition{
+					Type:   v1alpha2.IssuerConditionReady,
+					Status: cmmeta.ConditionTrue,
+				})
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Verifying the auto-provisioned ZeroSSL EAB credentials were stored")
+			eabSecret, err := f.KubeClientSet.CoreV1().Secrets(f.Namespace.Name).Get(context.TODO(), eabSecretName, metav1.GetOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(eabSecret.Data).To(HaveKey("key"))
+		})
+
+		AfterEach(func() {
+			By("Cleaning up")
+			f.CertManagerClientSet.CertmanagerV1alpha2().Issuers(f.Namespace.Name).Delete(context.TODO(), issuerName, metav1.DeleteOptions{})
+			f.KubeClientSet.CoreV1().Secrets(f.Namespace.Name).Delete(context.TODO(), testingACMEPrivateKey, metav1.DeleteOptions{})
+			f.KubeClientSet.CoreV1().Secrets(f.Namespace.Name).Delete(context.TODO(), eabSecretName, metav1.DeleteOptions{})
+			f.KubeClientSet.CoreV1().Secrets(f.Namespace.Name).Delete(context.TODO(), certificateSecretName, metav1.DeleteOptions{})
+		})
+
+		It("should obtain a signed certificate with a CommonName-bearing CSR", func() {
+			By("Creating a Certificate")
+
+			cert := gen.Certificate(certificateName,
+				gen.SetCertificateSecretName(certificateSecretName),
+				gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: issuerName}),
+				gen.SetCertificateCommonName(dnsDomain),
+				gen.SetCertificateDNSNames(dnsDomain),
+			)
+			cert.Namespace = f.Namespace.Name
+
+			cert, err := f.CertManagerClientSet.CertmanagerV1alpha2().Certificates(f.Namespace.Name).Create(context.TODO(), cert, metav1.CreateOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			err = h.WaitCertificateIssuedValid(f.Namespace.Name, certificateName, time.Minute*5)
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Verifying the issued leaf certificate has a matching CommonName")
+			secret, err := f.KubeClientSet.CoreV1().Secrets(f.Namespace.Name).Get(context.TODO(), certificateSecretName, metav1.GetOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			block, _ := pem.Decode(secret.Data[corev1.TLSCertKey])
+			Expect(block).NotTo(BeNil())
+			leaf, err := x509.ParseCertificate(block.Bytes)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(leaf.Subject.CommonName).To(Equal(dnsDomain))
+		})
 	})
 }
@@ -0,0 +1,66 @@
+/*
+Copyright 2019 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dnsproviders
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+// delegatedZoneSuffix is appended to BaseDomain to form DelegatedZone: a
+// separate scratch zone, also served by this Details' nameserver, that a
+// CNAME at _acme-challenge.<domain> can be pointed at to test
+// CNAMEStrategy: Follow without granting the solver write access to
+// BaseDomain itself.
+const delegatedZoneSuffix = "delegated"
+
+// DelegatedZone returns the scratch zone CreateCNAMERecord points CNAMEs at.
+func (d *Details) DelegatedZone() string {
+	return delegatedZoneSuffix + "." + d.BaseDomain
+}
+
+// CreateCNAMERecord creates a CNAME record at name pointing at target,
+// against this Details' nameserver, via an RFC2136 dynamic update.
+func (d *Details) CreateCNAMERecord(ctx context.Context, name, target string) error {
+	rfc2136 := d.ProviderConfig.RFC2136
+	if rfc2136 == nil {
+		return fmt.Errorf("dnsproviders: CreateCNAMERecord requires an RFC2136 provider config")
+	}
+
+	m := new(dns.Msg)
+	m.SetUpdate(dns.Fqdn(name))
+
+	rr, err := dns.NewRR(fmt.Sprintf("%s 60 IN CNAME %s", dns.Fqdn(name), dns.Fqdn(target)))
+	if err != nil {
+		return fmt.Errorf("dnsproviders: failed to build CNAME record: %w", err)
+	}
+	m.Insert([]dns.RR{rr})
+
+	if rfc2136.TSIGKeyName != "" {
+		m.SetTsig(dns.Fqdn(rfc2136.TSIGKeyName), rfc2136.TSIGAlgorithm, 300, time.Now().Unix())
+	}
+
+	client := new(dns.Client)
+	_, _, err = client.ExchangeContext(ctx, m, rfc2136.Nameserver)
+	if err != nil {
+		return fmt.Errorf("dnsproviders: failed to send CNAME update to %s: %w", rfc2136.Nameserver, err)
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2019 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package dnsproviders provisions the external DNS zones the ACME DNS01 e2e
+// suite validates against, and describes how to reach them through a
+// cert-manager ACMEChallengeSolverDNS01.
+package dnsproviders
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync/atomic"
+
+	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1alpha2"
+)
+
+// Details describes a provisioned DNS zone under test: how a solver should
+// be configured to reach it (ProviderConfig), and how to mint fresh,
+// non-colliding test domains within it.
+type Details struct {
+	// BaseDomain is the zone new test domains are minted under, e.g.
+	// "rfc2136.example.com".
+	BaseDomain string
+
+	// ProviderConfig is the DNS01 solver configuration that reaches this
+	// zone.
+	ProviderConfig cmacme.ACMEChallengeSolverDNS01
+
+	testDomainSeq uint64
+}
+
+// NewTestDomain returns a fresh domain under BaseDomain, unique within this
+// Details' lifetime, so concurrently-run tests don't collide on the same
+// name.
+func (d *Details) NewTestDomain() string {
+	seq := atomic.AddUint64(&d.testDomainSeq, 1)
+	return fmt.Sprintf("test-%d.%s", seq, d.BaseDomain)
+}
+
+// RegistryConfig returns ProviderConfig's RFC2136 nameserver, TSIG key name
+// and TSIG algorithm re-encoded as the generic Config map an
+// ACMEChallengeSolverDNS01 uses to dispatch to a provider registered by name
+// via pkg/issuer/acme/dns.RegisterDNSProvider, rather than through the typed
+// RFC2136 field. Empty values and the TSIGSecret reference are not included.
+// This lets the same zone be exercised through both configuration styles.
+func (d *Details) RegistryConfig() map[string]json.RawMessage {
+	cfg := map[string]json.RawMessage{}
+	rfc2136 := d.ProviderConfig.RFC2136
+	if rfc2136 == nil {
+		return cfg
+	}
+
+	for key, value := range map[string]string{
+		"nameserver":    rfc2136.Nameserver,
+		"tsigKeyName":   rfc2136.TSIGKeyName,
+		"tsigAlgorithm": rfc2136.TSIGAlgorithm,
+	} {
+		if value == "" {
+			continue
+		}
+		encoded, _ := json.Marshal(value)
+		cfg[key] = encoded
+	}
+	return cfg
+}
+
+// RFC2136 is a test addon that provisions an RFC2136-speaking authoritative
+// nameserver for a scratch zone.
+type RFC2136 struct {
+	details Details
+}
+
+// Details returns the zone details for the provisioned nameserver.
+func (r *RFC2136) Details() *Details {
+	return &r.details
+}
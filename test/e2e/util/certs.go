@@ -0,0 +1,47 @@
+/*
+Copyright 2019 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package util provides helpers shared across the ACME e2e suite.
+package util
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+)
+
+// RootCertificate returns the topmost certificate in a PEM-encoded chain:
+// the final block if the chain includes its root, or the highest
+// intermediate otherwise. It returns nil if chainPEM contains no
+// certificates or any block fails to parse.
+func RootCertificate(chainPEM []byte) *x509.Certificate {
+	var last *x509.Certificate
+
+	rest := chainPEM
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil
+		}
+		last = cert
+	}
+
+	return last
+}
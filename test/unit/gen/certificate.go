@@ -0,0 +1,87 @@
+/*
+Copyright 2019 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gen
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
+	cmmeta "github.com/jetstack/cert-manager/pkg/apis/meta/v1"
+)
+
+// CertificateModifier applies a change to a Certificate being built by
+// Certificate.
+type CertificateModifier func(*v1alpha2.Certificate)
+
+// Certificate builds a Certificate named name, applying each mod in order.
+func Certificate(name string, mods ...CertificateModifier) *v1alpha2.Certificate {
+	c := &v1alpha2.Certificate{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+	}
+	for _, mod := range mods {
+		mod(c)
+	}
+	return c
+}
+
+// SetCertificateSecretName sets the Secret name the issued certificate and
+// key will be written to.
+func SetCertificateSecretName(secretName string) CertificateModifier {
+	return func(c *v1alpha2.Certificate) {
+		c.Spec.SecretName = secretName
+	}
+}
+
+// SetCertificateIssuer sets the Issuer or ClusterIssuer that should sign the
+// Certificate.
+func SetCertificateIssuer(issuerRef cmmeta.ObjectReference) CertificateModifier {
+	return func(c *v1alpha2.Certificate) {
+		c.Spec.IssuerRef = issuerRef
+	}
+}
+
+// SetCertificateDNSNames sets the Certificate's requested DNS subject
+// alternative names.
+func SetCertificateDNSNames(dnsNames ...string) CertificateModifier {
+	return func(c *v1alpha2.Certificate) {
+		c.Spec.DNSNames = dnsNames
+	}
+}
+
+// SetCertificateCommonName sets the Certificate's requested Subject common
+// name.
+func SetCertificateCommonName(commonName string) CertificateModifier {
+	return func(c *v1alpha2.Certificate) {
+		c.Spec.CommonName = commonName
+	}
+}
+
+// SetCertificateKeyAlgorithm sets the private key algorithm the controller
+// should use when generating the Certificate's CSR.
+func SetCertificateKeyAlgorithm(algorithm cmmeta.KeyAlgorithm) CertificateModifier {
+	return func(c *v1alpha2.Certificate) {
+		c.Spec.KeyAlgorithm = algorithm
+	}
+}
+
+// SetCertificateKeySize sets the requested private key size (RSA bits, or
+// ECDSA curve size).
+func SetCertificateKeySize(size int) CertificateModifier {
+	return func(c *v1alpha2.Certificate) {
+		c.Spec.KeySize = size
+	}
+}
@@ -0,0 +1,48 @@
+/*
+Copyright 2019 The Jetstack cert-manager contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package gen provides builders for cert-manager API types, for use in
+// tests that need a populated object without constructing one field by
+// field.
+package gen
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cmacme "github.com/jetstack/cert-manager/pkg/apis/acme/v1alpha2"
+	"github.com/jetstack/cert-manager/pkg/apis/certmanager/v1alpha2"
+)
+
+// IssuerModifier applies a change to an Issuer being built by Issuer.
+type IssuerModifier func(*v1alpha2.Issuer)
+
+// Issuer builds an Issuer named name, applying each mod in order.
+func Issuer(name string, mods ...IssuerModifier) *v1alpha2.Issuer {
+	i := &v1alpha2.Issuer{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+	}
+	for _, mod := range mods {
+		mod(i)
+	}
+	return i
+}
+
+// SetIssuerACME sets the Issuer's ACME configuration.
+func SetIssuerACME(acmeSpec cmacme.ACMEIssuer) IssuerModifier {
+	return func(i *v1alpha2.Issuer) {
+		i.Spec.ACME = &acmeSpec
+	}
+}